@@ -0,0 +1,354 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultBaseURL = "https://slack.com/api/"
+
+// TokenProvider resolves the access token to use for a workspace, for
+// callers managing more than one Slack installation (e.g. a multi-tenant
+// app) that want to look tokens up from their own store rather than
+// passing one in on every call.
+type TokenProvider interface {
+	ResolveToken(ctx context.Context, workspaceID string) (string, error)
+}
+
+// httpClient is the transport shared by every generated *Service. It owns
+// the underlying *http.Client, the RateLimiter consulted before each
+// request, and the retry/backoff policy applied when Slack responds with a
+// rate limit error.
+type httpClient struct {
+	hc          *http.Client
+	baseURL     string
+	limiter     RateLimiter
+	maxRetries  int
+	backoff     func(attempt int) time.Duration
+	middlewares []Middleware
+	tokens      TokenProvider
+}
+
+// ClientOption configures an httpClient returned by newHTTPClient.
+type ClientOption func(*httpClient)
+
+// WithMaxRetries caps how many times httpClient.postForm will retry a call
+// after a rate limit response before giving up. The default is 3.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *httpClient) { c.maxRetries = n }
+}
+
+// WithBackoff overrides the delay httpClient falls back on when a rate
+// limited response carries no usable Retry-After header. attempt is 0 on
+// the first retry.
+func WithBackoff(f func(attempt int) time.Duration) ClientOption {
+	return func(c *httpClient) { c.backoff = f }
+}
+
+// WithRateLimiter overrides the RateLimiter consulted before every request.
+// The default limits each Tier to Slack's documented per-minute budget.
+func WithRateLimiter(rl RateLimiter) ClientOption {
+	return func(c *httpClient) { c.limiter = rl }
+}
+
+// WithBaseURL overrides the URL endpoints are resolved against, in place of
+// defaultBaseURL. Used to point a Client at a fake server such as
+// slacktest.FakeSlack for testing.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *httpClient) { c.baseURL = baseURL }
+}
+
+// WithHTTPClient overrides the *http.Client used for every request, in
+// place of http.DefaultClient. Used to install a custom Transport such as
+// slacktest.Recorder or slacktest.Replayer.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *httpClient) { c.hc = hc }
+}
+
+// WithMiddleware adds middleware that wraps every request the client makes
+// (form, JSON and multipart alike), outermost to innermost in the order passed. Per-call
+// middleware added via WithCallMiddleware on a single Do runs inside these.
+func WithMiddleware(m ...Middleware) ClientOption {
+	return func(c *httpClient) { c.middlewares = append(c.middlewares, m...) }
+}
+
+// WithTokenProvider registers a TokenProvider used to resolve the token for
+// any call scoped to a workspace via its WorkspaceID setter, when the call
+// itself doesn't supply one via its Token setter.
+func WithTokenProvider(tp TokenProvider) ClientOption {
+	return func(c *httpClient) { c.tokens = tp }
+}
+
+func newHTTPClient(opts ...ClientOption) *httpClient {
+	c := &httpClient{
+		hc:         http.DefaultClient,
+		baseURL:    defaultBaseURL,
+		limiter:    newDefaultRateLimiter(),
+		maxRetries: 3,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func defaultBackoff(attempt int) time.Duration {
+	return time.Duration(attempt+1) * time.Second
+}
+
+// resolveToken picks the token a call should authenticate with: an explicit
+// per-call token wins, then a workspace looked up through c.tokens, falling
+// back to the token the Service itself was constructed with.
+func (c *httpClient) resolveToken(ctx context.Context, serviceToken, callToken, workspaceID string) (string, error) {
+	if len(callToken) > 0 {
+		return callToken, nil
+	}
+	if len(workspaceID) > 0 && c.tokens != nil {
+		token, err := c.tokens.ResolveToken(ctx, workspaceID)
+		if err != nil {
+			return "", errors.Wrapf(err, `failed to resolve token for workspace %s`, workspaceID)
+		}
+		return token, nil
+	}
+	return serviceToken, nil
+}
+
+// postForm submits v to endpoint and decodes the response into dst. If
+// Slack answers with HTTP 429 or an `"error":"ratelimited"` body, postForm
+// honors the Retry-After header (falling back to c.backoff when absent),
+// waits for it or ctx to be done, and retries up to c.maxRetries times.
+// Before every attempt, including the first, it blocks on c.limiter so
+// callers get correct backpressure without wrapping Do() themselves. token
+// is the already-resolved token for this call; if useAuthHeader is true it
+// is sent as an Authorization: Bearer header instead of the form's token
+// field.
+func (c *httpClient) postForm(ctx context.Context, endpoint string, tier Tier, v url.Values, dst interface{}, token string, useAuthHeader bool, opts ...CallOption) (int, error) {
+	cfg := newCallConfig(opts...)
+	mws := append(append([]Middleware{}, c.middlewares...), cfg.middlewares...)
+	return c.withRetry(ctx, endpoint, tier, token, func() (int, string, bool, error) {
+		return c.doPostForm(ctx, endpoint, v, dst, mws, token, useAuthHeader)
+	})
+}
+
+// postJSON marshals payload as a JSON object and posts it to endpoint,
+// decoding the response into dst. Retry/rate-limit semantics, middleware
+// layering, and the token/useAuthHeader behavior all match postForm; since
+// Middleware is typed around url.Values, payload is flattened through
+// valuesFromPayload before being handed to the chain, purely so middleware
+// like tracing/metrics hooks see the same shape of request regardless of
+// transport. The wire request itself still sends payload as JSON.
+func (c *httpClient) postJSON(ctx context.Context, endpoint string, tier Tier, payload map[string]interface{}, dst interface{}, token string, useAuthHeader bool, opts ...CallOption) (int, error) {
+	cfg := newCallConfig(opts...)
+	mws := append(append([]Middleware{}, c.middlewares...), cfg.middlewares...)
+	return c.withRetry(ctx, endpoint, tier, token, func() (int, string, bool, error) {
+		return c.doPostJSON(ctx, endpoint, payload, dst, mws, token, useAuthHeader)
+	})
+}
+
+// postMultipart posts fields plus, if fileField is non-empty, a file part
+// streamed from file under that field name (used by endpoints like
+// files.upload). Retry/rate-limit semantics, middleware layering, and the
+// token/useAuthHeader behavior all match postForm; see postJSON for why
+// fields is flattened through valuesFromPayload for the middleware chain
+// (file contents aren't included, since NextFunc only carries form values).
+func (c *httpClient) postMultipart(ctx context.Context, endpoint string, tier Tier, fields map[string]interface{}, fileField string, file io.Reader, dst interface{}, token string, useAuthHeader bool, opts ...CallOption) (int, error) {
+	cfg := newCallConfig(opts...)
+	mws := append(append([]Middleware{}, c.middlewares...), cfg.middlewares...)
+	return c.withRetry(ctx, endpoint, tier, token, func() (int, string, bool, error) {
+		return c.doPostMultipart(ctx, endpoint, fields, fileField, file, dst, mws, token, useAuthHeader)
+	})
+}
+
+// valuesFromPayload flattens a JSON/multipart field map into url.Values so
+// it can pass through the same Middleware chain postForm uses, which is
+// typed around url.Values. Values are stringified with fmt.Sprintf, so a
+// middleware inspecting a non-string field (e.g. blocks) sees its Go
+// representation rather than the JSON actually sent on the wire.
+func valuesFromPayload(payload map[string]interface{}) url.Values {
+	v := make(url.Values, len(payload))
+	for k, val := range payload {
+		v.Set(k, fmt.Sprintf("%v", val))
+	}
+	return v
+}
+
+// withRetry runs do, waiting on c.limiter before every attempt including
+// the first. limiterKey scopes the rate limit budget below tier - the
+// resolved token, so one workspace's traffic doesn't throttle another's on
+// a shared httpClient. If do reports a rate-limited failure it honors the
+// Retry-After value it returned (falling back to c.backoff when empty),
+// waits for it or ctx to be done, and retries up to c.maxRetries times. If
+// every attempt is rate limited, it returns a *SlackAPIError with
+// ErrRateLimited's code so callers can still errors.Is(err, ErrRateLimited)
+// after retries are exhausted.
+func (c *httpClient) withRetry(ctx context.Context, endpoint string, tier Tier, limiterKey string, do func() (status int, retryAfterHeader string, rateLimited bool, err error)) (int, error) {
+	var lastStatus int
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, tier, limiterKey); err != nil {
+				return 0, errors.Wrap(err, `rate limiter wait failed`)
+			}
+		}
+
+		status, retryAfterHeader, rateLimited, err := do()
+		if err == nil {
+			return status, nil
+		}
+		if !rateLimited {
+			return status, err
+		}
+		lastStatus = status
+		if attempt >= c.maxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastStatus, errors.Wrap(ctx.Err(), `context done while waiting to retry`)
+		case <-time.After(c.retryAfter(retryAfterHeader, attempt)):
+		}
+	}
+	return lastStatus, &SlackAPIError{
+		Code:       ErrRateLimited.Code,
+		Endpoint:   endpoint,
+		HTTPStatus: lastStatus,
+		Messages:   []string{fmt.Sprintf(`exceeded %d retries`, c.maxRetries)},
+	}
+}
+
+// doPostForm performs a single attempt. rateLimited reports whether the
+// failure was a rate limit response worth retrying, in which case header
+// carries the raw Retry-After header value (possibly empty); any other
+// error is terminal. status is the HTTP status code observed, or 0 if the
+// request never completed.
+func (c *httpClient) doPostForm(ctx context.Context, endpoint string, v url.Values, dst interface{}, mws []Middleware, token string, useAuthHeader bool) (status int, header string, rateLimited bool, err error) {
+	terminal := func(ctx context.Context, endpoint string, v url.Values) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, strings.NewReader(v.Encode()))
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to create request`)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if useAuthHeader && len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return c.hc.Do(req)
+	}
+
+	res, err := chainMiddleware(mws, terminal)(ctx, endpoint, v)
+	if err != nil {
+		return 0, "", false, errors.Wrap(err, `failed to execute request`)
+	}
+	return c.decodeResponse(res, endpoint, dst)
+}
+
+// doPostJSON performs a single JSON-transport attempt. Return values follow
+// the same convention as doPostForm.
+func (c *httpClient) doPostJSON(ctx context.Context, endpoint string, payload map[string]interface{}, dst interface{}, mws []Middleware, token string, useAuthHeader bool) (status int, header string, rateLimited bool, err error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, "", false, errors.Wrap(err, `failed to marshal payload`)
+	}
+
+	terminal := func(ctx context.Context, endpoint string, _ url.Values) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to create request`)
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		if useAuthHeader && len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return c.hc.Do(req)
+	}
+
+	res, err := chainMiddleware(mws, terminal)(ctx, endpoint, valuesFromPayload(payload))
+	if err != nil {
+		return 0, "", false, errors.Wrap(err, `failed to execute request`)
+	}
+	return c.decodeResponse(res, endpoint, dst)
+}
+
+// doPostMultipart performs a single multipart-transport attempt. Return
+// values follow the same convention as doPostForm.
+func (c *httpClient) doPostMultipart(ctx context.Context, endpoint string, fields map[string]interface{}, fileField string, file io.Reader, dst interface{}, mws []Middleware, token string, useAuthHeader bool) (status int, header string, rateLimited bool, err error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		if err := w.WriteField(k, fmt.Sprintf("%v", v)); err != nil {
+			return 0, "", false, errors.Wrapf(err, `failed to write multipart field %s`, k)
+		}
+	}
+	if len(fileField) > 0 && file != nil {
+		part, err := w.CreateFormFile(fileField, fileField)
+		if err != nil {
+			return 0, "", false, errors.Wrap(err, `failed to create multipart file part`)
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			return 0, "", false, errors.Wrap(err, `failed to stream file contents`)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return 0, "", false, errors.Wrap(err, `failed to close multipart writer`)
+	}
+	contentType := w.FormDataContentType()
+
+	terminal := func(ctx context.Context, endpoint string, _ url.Values) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, &body)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to create request`)
+		}
+		req.Header.Set("Content-Type", contentType)
+		if useAuthHeader && len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return c.hc.Do(req)
+	}
+
+	res, err := chainMiddleware(mws, terminal)(ctx, endpoint, valuesFromPayload(fields))
+	if err != nil {
+		return 0, "", false, errors.Wrap(err, `failed to execute request`)
+	}
+	return c.decodeResponse(res, endpoint, dst)
+}
+
+// decodeResponse checks res for a rate limit response and, if it isn't one,
+// decodes its body into dst.
+func (c *httpClient) decodeResponse(res *http.Response, endpoint string, dst interface{}) (status int, header string, rateLimited bool, err error) {
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, "", false, errors.Wrap(err, `failed to read response body`)
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests || bytes.Contains(body, []byte(`"error":"ratelimited"`)) {
+		return res.StatusCode, res.Header.Get("Retry-After"), true, errors.Errorf(`rate limited by slack on %s`, endpoint)
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		return res.StatusCode, "", false, errors.Wrap(err, `failed to decode response`)
+	}
+	return res.StatusCode, "", false, nil
+}
+
+// retryAfter parses Slack's Retry-After header (always integer seconds per
+// their docs) and falls back to c.backoff(attempt) if the header is absent
+// or malformed.
+func (c *httpClient) retryAfter(header string, attempt int) time.Duration {
+	if secs, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return c.backoff(attempt)
+}
@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubTokenProvider struct {
+	token string
+	err   error
+}
+
+func (s stubTokenProvider) ResolveToken(ctx context.Context, workspaceID string) (string, error) {
+	return s.token, s.err
+}
+
+func TestResolveToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceTok  string
+		callTok     string
+		workspaceID string
+		tokens      TokenProvider
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:       "call token wins over everything",
+			serviceTok: "service-tok",
+			callTok:    "call-tok",
+			want:       "call-tok",
+		},
+		{
+			name:        "workspace resolves via TokenProvider when no call token",
+			serviceTok:  "service-tok",
+			workspaceID: "T123",
+			tokens:      stubTokenProvider{token: "workspace-tok"},
+			want:        "workspace-tok",
+		},
+		{
+			name:       "falls back to service token with no call token or workspace",
+			serviceTok: "service-tok",
+			want:       "service-tok",
+		},
+		{
+			name:        "workspace set but no TokenProvider falls back to service token",
+			serviceTok:  "service-tok",
+			workspaceID: "T123",
+			want:        "service-tok",
+		},
+		{
+			name:        "TokenProvider error propagates",
+			workspaceID: "T123",
+			tokens:      stubTokenProvider{err: errors.New("boom")},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &httpClient{tokens: tt.tokens}
+			got, err := c.resolveToken(context.Background(), tt.serviceTok, tt.callTok, tt.workspaceID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package slack
+
+import "fmt"
+
+// ErrorCode is the machine-readable `error` field Slack puts on a failed
+// API response, e.g. "channel_not_found".
+type ErrorCode string
+
+func (e ErrorCode) String() string { return string(e) }
+
+// ResponseMetadata mirrors Slack's `response_metadata` object, present on
+// many responses to carry non-fatal warnings and, on paginated endpoints,
+// the cursor for the next page.
+type ResponseMetadata struct {
+	Warnings   []string `json:"warnings,omitempty"`
+	Messages   []string `json:"messages,omitempty"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+// SlackResponse is embedded by the response struct of every generated Call.
+// Its fields are populated straight off the JSON envelope Slack wraps every
+// Web API response in.
+type SlackResponse struct {
+	OK               bool             `json:"ok"`
+	Error            ErrorCode        `json:"error,omitempty"`
+	Warning          string           `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+// SlackAPIError is returned by a generated Do() when Slack answers with
+// `"ok": false`. It keeps the structure of the failure instead of
+// collapsing it into a plain string, so callers can either inspect it
+// directly or use errors.Is against one of the Err* sentinels below.
+type SlackAPIError struct {
+	Code       ErrorCode
+	Endpoint   string
+	HTTPStatus int
+	Warnings   []string
+	Messages   []string
+}
+
+func (e *SlackAPIError) Error() string {
+	return fmt.Sprintf("slack: %s: %s (http %d)", e.Endpoint, e.Code, e.HTTPStatus)
+}
+
+// Is lets errors.Is(err, slack.ErrChannelNotFound) match any SlackAPIError
+// with the same Code, regardless of which endpoint produced it.
+func (e *SlackAPIError) Is(target error) bool {
+	t, ok := target.(*SlackAPIError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// Sentinel errors for the error codes callers most commonly need to branch
+// on. This is not an exhaustive catalog of Slack's error codes; it only
+// covers those encountered often enough in practice to warrant a name.
+// Compare against these with errors.Is, or type-assert to *SlackAPIError
+// to inspect Warnings, Messages, and HTTPStatus for any other code.
+var (
+	ErrRateLimited     = &SlackAPIError{Code: "ratelimited"}
+	ErrChannelNotFound = &SlackAPIError{Code: "channel_not_found"}
+	ErrTokenRevoked    = &SlackAPIError{Code: "token_revoked"}
+	ErrNotAuthed       = &SlackAPIError{Code: "not_authed"}
+	ErrInvalidAuth     = &SlackAPIError{Code: "invalid_auth"}
+)
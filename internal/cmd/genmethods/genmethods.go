@@ -31,8 +31,17 @@ type Endpoint struct {
 	Args       []Argument `json:"args,omitempty"`
 	ReturnType string     `json:"return,omitempty"`
 	SkipToken  bool       `json:"skip_token,omitempty"`
+	Tier       string     `json:"tier,omitempty"`        // Slack rate limit tier, e.g. "tier2". Defaults to "tier3".
+	Iterator   string     `json:"iterator,omitempty"`    // "cursor" to additionally generate Iter/Pages for this endpoint.
+	Transport  string     `json:"transport,omitempty"`   // "form" (default), "json", or "multipart".
+	AuthHeader bool       `json:"auth_header,omitempty"` // true to send the token as "Authorization: Bearer ..." instead of a token= field.
 }
 
+// defaultTier is the rate limit tier applied to endpoints that don't declare
+// one explicitly in endpoints.json. Most Slack Web API methods fall under
+// Tier 3, so that's the safest default.
+const defaultTier = "tier3"
+
 type Argument struct {
 	Name      string `json:"name"`
 	Type      string `json:"type"`
@@ -99,6 +108,70 @@ func _main() error {
 			return errors.Wrapf(err, `failed to generate file %s`, fn)
 		}
 	}
+
+	if err := generateSlacktestRequirementsFile(endpoints); err != nil {
+		return errors.Wrap(err, `failed to generate slacktest requirements file`)
+	}
+	return nil
+}
+
+// generateSlacktestRequirementsFile emits slacktest/requirements_generated.go,
+// a map from endpoint name to its Required argument names. This reuses the
+// exact Required flags generateServiceDetailsFile reads to build the
+// Values()/Payload() checks, so slacktest.FakeSlack can validate incoming
+// requests the same way a real generated Call would before ever reaching
+// Slack, without slacktest needing to know about every Call type.
+func generateSlacktestRequirementsFile(endpoints []Endpoint) error {
+	sort.Slice(endpoints, func(i, j int) bool {
+		return strings.Compare(endpoints[i].Name, endpoints[j].Name) < 0
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("package slacktest")
+	buf.WriteString("\n\n// Auto-generated by internal/cmd/genmethods/genmethods.go. DO NOT EDIT!")
+	buf.WriteString("\n\n// requiredParams maps a Slack endpoint name to the form/JSON field names")
+	buf.WriteString("\n// its generated Call treats as required.")
+	buf.WriteString("\nvar requiredParams = map[string][]string{")
+	for _, endpoint := range endpoints {
+		var names []string
+		for _, arg := range endpoint.Args {
+			if !arg.Required {
+				continue
+			}
+			name := arg.Name
+			if len(arg.QueryName) > 0 {
+				name = arg.QueryName
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintf(&buf, "\n%s: {", strconv.Quote(endpoint.Name))
+		for _, name := range names {
+			fmt.Fprintf(&buf, "%s, ", strconv.Quote(name))
+		}
+		buf.WriteString("},")
+	}
+	buf.WriteString("\n}")
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Printf("%s", buf.Bytes())
+		return errors.Wrap(err, `failed to format code`)
+	}
+
+	if err := os.MkdirAll("slacktest", 0755); err != nil {
+		return errors.Wrap(err, `failed to create slacktest directory`)
+	}
+
+	file := "slacktest/requirements_generated.go"
+	fh, err := os.Create(file)
+	if err != nil {
+		return errors.Wrapf(err, `failed to open file %s for writing`, file)
+	}
+	defer fh.Close()
+
+	fh.Write(formatted)
 	return nil
 }
 
@@ -137,16 +210,190 @@ func generateServicesFile(groups map[string]struct{}) error {
 	return nil
 }
 
+// generateIteratorCode emits, for a cursor-paginated endpoint, an
+// iteratorName type with Next/Item/Err plus a Pages helper on the Call
+// itself. Both drive repeated calls to Do, threading response_metadata's
+// next_cursor back in through the Call's existing Cursor setter until
+// Slack returns an empty cursor. returnType is the (possibly pointer-ed)
+// Go type Do returns, e.g. "objects.ChannelList"; by this generator's List
+// convention its single-item accessor is Items() []*Elem.
+func generateIteratorCode(buf *bytes.Buffer, endpoint Endpoint, returnType string) {
+	callName := endpoint.Group + endpoint.methodName + "Call"
+	iterName := endpoint.Group + endpoint.methodName + "Iterator"
+	elemType := "*" + strings.TrimSuffix(returnType, "List")
+
+	fmt.Fprintf(buf, "\n\n// %s iterates over cursor-paginated pages of the %s endpoint", iterName, endpoint.Name)
+	fmt.Fprintf(buf, "\ntype %s struct {", iterName)
+	fmt.Fprintf(buf, "\ncall *%s", callName)
+	buf.WriteString("\nctx context.Context")
+	fmt.Fprintf(buf, "\npage %s", returnType)
+	buf.WriteString("\nidx int")
+	buf.WriteString("\ndone bool")
+	buf.WriteString("\nerr error")
+	buf.WriteString("\n}")
+
+	fmt.Fprintf(buf, "\n\n// Iter returns a %s that transparently re-issues %s with each", iterName, callName)
+	buf.WriteString("\n// successive cursor until Slack signals exhaustion.")
+	fmt.Fprintf(buf, "\nfunc (c *%s) Iter(ctx context.Context) *%s {", callName, iterName)
+	fmt.Fprintf(buf, "\nreturn &%s{call: c, ctx: ctx}", iterName)
+	buf.WriteString("\n}")
+
+	fmt.Fprintf(buf, "\n\n// Next advances the iterator, fetching another page from Slack as needed.")
+	buf.WriteString("\n// It returns false once iteration is exhausted or an error occurred; check Err in the latter case.")
+	fmt.Fprintf(buf, "\nfunc (it *%s) Next() bool {", iterName)
+	fmt.Fprintf(buf, "\nfor it.page == nil || it.idx >= len(it.page.Items()) {")
+	buf.WriteString("\nif it.done {")
+	buf.WriteString("\nreturn false")
+	buf.WriteString("\n}")
+	buf.WriteString("\npage, err := it.call.Do(it.ctx)")
+	buf.WriteString("\nif err != nil {")
+	buf.WriteString("\nit.err = err")
+	buf.WriteString("\nreturn false")
+	buf.WriteString("\n}")
+	buf.WriteString("\nit.page = page")
+	buf.WriteString("\nit.idx = 0")
+	buf.WriteString("\nif len(it.call.nextCursor) == 0 {")
+	buf.WriteString("\nit.done = true")
+	buf.WriteString("\n} else {")
+	buf.WriteString("\nit.call.Cursor(it.call.nextCursor)")
+	buf.WriteString("\n}")
+	buf.WriteString("\nif len(page.Items()) == 0 && it.done {")
+	buf.WriteString("\nreturn false")
+	buf.WriteString("\n}")
+	buf.WriteString("\n}")
+	buf.WriteString("\nit.idx++")
+	buf.WriteString("\nreturn true")
+	buf.WriteString("\n}")
+
+	fmt.Fprintf(buf, "\n\n// Item returns the current item. It is only valid after a call to Next that returned true.")
+	fmt.Fprintf(buf, "\nfunc (it *%s) Item() %s {", iterName, elemType)
+	buf.WriteString("\nreturn it.page.Items()[it.idx-1]")
+	buf.WriteString("\n}")
+
+	buf.WriteString("\n\n// Err returns the first error encountered while iterating, if any.")
+	fmt.Fprintf(buf, "\nfunc (it *%s) Err() error {", iterName)
+	buf.WriteString("\nreturn it.err")
+	buf.WriteString("\n}")
+
+	fmt.Fprintf(buf, "\n\n// Pages calls f once per page of the %s endpoint until exhaustion or f returns an error.", endpoint.Name)
+	fmt.Fprintf(buf, "\nfunc (c *%s) Pages(ctx context.Context, f func(%s) error) error {", callName, returnType)
+	buf.WriteString("\nfor {")
+	buf.WriteString("\npage, err := c.Do(ctx)")
+	buf.WriteString("\nif err != nil {")
+	buf.WriteString("\nreturn err")
+	buf.WriteString("\n}")
+	buf.WriteString("\nif err := f(page); err != nil {")
+	buf.WriteString("\nreturn err")
+	buf.WriteString("\n}")
+	buf.WriteString("\nif len(c.nextCursor) == 0 {")
+	buf.WriteString("\nreturn nil")
+	buf.WriteString("\n}")
+	buf.WriteString("\nc.Cursor(c.nextCursor)")
+	buf.WriteString("\n}")
+	buf.WriteString("\n}")
+}
+
+// generatePayloadFunc emits Payload(), the json/multipart counterpart to
+// Values(): it runs the same required/optional validation but collects
+// native Go values into a map instead of url-encoding them, since both
+// postJSON and postMultipart marshal/encode field-by-field themselves. An
+// arg typed "io.Reader" is the file body for multipart transport and isn't
+// added to the map here; Do() passes it to postMultipart separately. If
+// such an arg is required, Payload still fails fast with the same "missing
+// required parameter" error the other types get, so a nil file is caught
+// client-side instead of silently posting without one.
+func generatePayloadFunc(buf *bytes.Buffer, endpoint Endpoint, transport string) {
+	callName := endpoint.Group + endpoint.methodName + "Call"
+	fmt.Fprintf(buf, "\n\n// Payload returns the %s object as a field map for %s transport", callName, transport)
+	fmt.Fprintf(buf, "\nfunc (c *%s) Payload(token string) (map[string]interface{}, error) {", callName)
+	buf.WriteString("\np := map[string]interface{}{}")
+	if !endpoint.SkipToken && !endpoint.AuthHeader {
+		buf.WriteString("\np[`token`] = token")
+	}
+
+	for _, arg := range endpoint.Args {
+		if arg.Type == "io.Reader" {
+			if arg.Required {
+				fmt.Fprintf(buf, "\nif c.%s == nil {", arg.Name)
+				fmt.Fprintf(buf, "\nreturn nil, errors.New(`missing required parameter %s`)", arg.Name)
+				buf.WriteString("\n}")
+			}
+			continue
+		}
+
+		var requiredCheck, optionalCheck string
+		switch arg.Type {
+		case "string":
+			requiredCheck = fmt.Sprintf("\nif len(c.%s) <= 0 {", arg.Name)
+			optionalCheck = fmt.Sprintf("\nif len(c.%s) > 0 {", arg.Name)
+		case "bool":
+			requiredCheck = fmt.Sprintf("\nif !c.%s {", arg.Name)
+			optionalCheck = fmt.Sprintf("\nif c.%s {", arg.Name)
+		case "int":
+			requiredCheck = fmt.Sprintf("\nif c.%s == 0 {", arg.Name)
+			optionalCheck = fmt.Sprintf("\nif c.%s > 0 {", arg.Name)
+		default:
+			if strings.HasSuffix(arg.Type, "List") {
+				requiredCheck = fmt.Sprintf("\nif len(c.%s) <= 0 {", arg.Name)
+				optionalCheck = fmt.Sprintf("\nif len(c.%s) > 0 {", arg.Name)
+			} else {
+				requiredCheck = fmt.Sprintf("\nif c.%s == nil {", arg.Name)
+				optionalCheck = fmt.Sprintf("\nif c.%s != nil {", arg.Name)
+			}
+		}
+
+		buf.WriteString("\n")
+		if arg.Required {
+			buf.WriteString(requiredCheck)
+			fmt.Fprintf(buf, "\nreturn nil, errors.New(`missing required parameter %s`)", arg.Name)
+			buf.WriteString("\n}")
+		} else {
+			buf.WriteString(optionalCheck)
+		}
+
+		var qn = arg.Name
+		if len(arg.QueryName) > 0 {
+			qn = arg.QueryName
+		}
+		fmt.Fprintf(buf, "\np[%s] = c.%s", strconv.Quote(qn), arg.Name)
+
+		if !arg.Required {
+			buf.WriteString("\n}")
+		}
+	}
+	buf.WriteString("\nreturn p, nil")
+	buf.WriteString("\n}")
+}
+
 func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 	sort.Slice(endpoints, func(i, j int) bool {
 		return strings.Compare(endpoints[i].Name, endpoints[j].Name) < 0
 	})
 
+	var usesForm, usesMultipart bool
+	for _, endpoint := range endpoints {
+		switch endpoint.Transport {
+		case "multipart":
+			usesMultipart = true
+		case "json":
+		default:
+			usesForm = true
+		}
+	}
+
 	var buf bytes.Buffer
 	buf.WriteString("\npackage slack")
 	buf.WriteString("\n\n// Auto-generated by internal/cmd/genmethods/genmethods.go. DO NOT EDIT!")
 	buf.WriteString("\n\nimport (")
-	for _, pkg := range []string{"context", "net/url", "strconv"} {
+	stdPkgs := []string{"context", "strconv"}
+	if usesForm {
+		stdPkgs = append(stdPkgs, "net/url")
+	}
+	if usesMultipart {
+		stdPkgs = append(stdPkgs, "io")
+	}
+	sort.Strings(stdPkgs)
+	for _, pkg := range stdPkgs {
 		fmt.Fprintf(&buf, "\n%s", strconv.Quote(pkg))
 	}
 	buf.WriteString("\n")
@@ -174,6 +421,15 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 			}
 		}
 
+		if endpoint.Iterator == "cursor" {
+			buf.WriteString("\nnextCursor string // set by Do, consumed by Iter/Pages to fetch the following page")
+		}
+
+		if !endpoint.SkipToken {
+			buf.WriteString("\ntoken string // overrides service.token when set, via Token")
+			buf.WriteString("\nworkspaceID string // resolved against the client's TokenProvider when token is unset, via WorkspaceID")
+		}
+
 		buf.WriteString("\n}")
 	}
 
@@ -237,70 +493,94 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 			}
 		}
 
-		fmt.Fprintf(&buf, "\n\n// Values returns the %s%sCall object as url.Values", endpoint.Group, endpoint.methodName)
-		fmt.Fprintf(&buf, "\nfunc (c *%s%sCall) Values() (url.Values, error) {", endpoint.Group, endpoint.methodName)
-		buf.WriteString("\nv := url.Values{}")
 		if !endpoint.SkipToken {
-			buf.WriteString("\nv.Set(`token`, c.service.token)")
+			callName := endpoint.Group + endpoint.methodName + "Call"
+			fmt.Fprintf(&buf, "\n\n// Token overrides the token this call authenticates with, taking precedence over both the service's token and WorkspaceID.")
+			fmt.Fprintf(&buf, "\nfunc (c *%s) Token(token string) *%s {", callName, callName)
+			buf.WriteString("\nc.token = token")
+			buf.WriteString("\nreturn c")
+			buf.WriteString("\n}")
+
+			fmt.Fprintf(&buf, "\n\n// WorkspaceID scopes this call to a workspace, resolved to a token via the client's TokenProvider. Ignored if Token is also set.")
+			fmt.Fprintf(&buf, "\nfunc (c *%s) WorkspaceID(workspaceID string) *%s {", callName, callName)
+			buf.WriteString("\nc.workspaceID = workspaceID")
+			buf.WriteString("\nreturn c")
+			buf.WriteString("\n}")
 		}
-		for _, arg := range endpoint.Args {
-			var requiredCheck string
-			var optionalCheck string
-			var assignValue string
-			var prelude string
-
-			assignValue = fmt.Sprintf("c.%s", arg.Name)
-			switch arg.Type {
-			case "string":
-				requiredCheck = fmt.Sprintf("\nif len(c.%s) <= 0 {", arg.Name)
-				optionalCheck = fmt.Sprintf("\nif len(c.%s) > 0 {", arg.Name)
-			case "bool":
-				requiredCheck = fmt.Sprintf("\nif !c.%s {", arg.Name)
-				optionalCheck = fmt.Sprintf("\nif c.%s {", arg.Name)
-				assignValue = `"true"`
-			case "int":
-				requiredCheck = fmt.Sprintf("\nif c.%s == 0 {", arg.Name)
-				optionalCheck = fmt.Sprintf("\nif c.%s > 0 {", arg.Name)
-				assignValue = fmt.Sprintf(`strconv.Itoa(c.%s)`, arg.Name)
-			default:
-				prelude = fmt.Sprintf("\n%sEncoded, err := c.%s.Encode()\nif err != nil {\nreturn nil, errors.Wrap(err, `failed to encode field`)\n}", arg.Name, arg.Name)
-				assignValue = fmt.Sprintf("%sEncoded", arg.Name)
-				if strings.HasSuffix(arg.Type, "List") {
+
+		transport := endpoint.Transport
+		if len(transport) == 0 {
+			transport = "form"
+		}
+
+		if transport == "form" {
+			fmt.Fprintf(&buf, "\n\n// Values returns the %s%sCall object as url.Values. token is only embedded as the `token` field when the endpoint doesn't use Authorization-header auth.", endpoint.Group, endpoint.methodName)
+			fmt.Fprintf(&buf, "\nfunc (c *%s%sCall) Values(token string) (url.Values, error) {", endpoint.Group, endpoint.methodName)
+			buf.WriteString("\nv := url.Values{}")
+			if !endpoint.SkipToken && !endpoint.AuthHeader {
+				buf.WriteString("\nv.Set(`token`, token)")
+			}
+			for _, arg := range endpoint.Args {
+				var requiredCheck string
+				var optionalCheck string
+				var assignValue string
+				var prelude string
+
+				assignValue = fmt.Sprintf("c.%s", arg.Name)
+				switch arg.Type {
+				case "string":
 					requiredCheck = fmt.Sprintf("\nif len(c.%s) <= 0 {", arg.Name)
 					optionalCheck = fmt.Sprintf("\nif len(c.%s) > 0 {", arg.Name)
-				} else {
-					requiredCheck = fmt.Sprintf("\nif c.%s == nil {", arg.Name)
-					optionalCheck = fmt.Sprintf("\nif c.%s != nil {", arg.Name)
+				case "bool":
+					requiredCheck = fmt.Sprintf("\nif !c.%s {", arg.Name)
+					optionalCheck = fmt.Sprintf("\nif c.%s {", arg.Name)
+					assignValue = `"true"`
+				case "int":
+					requiredCheck = fmt.Sprintf("\nif c.%s == 0 {", arg.Name)
+					optionalCheck = fmt.Sprintf("\nif c.%s > 0 {", arg.Name)
+					assignValue = fmt.Sprintf(`strconv.Itoa(c.%s)`, arg.Name)
+				default:
+					prelude = fmt.Sprintf("\n%sEncoded, err := c.%s.Encode()\nif err != nil {\nreturn nil, errors.Wrap(err, `failed to encode field`)\n}", arg.Name, arg.Name)
+					assignValue = fmt.Sprintf("%sEncoded", arg.Name)
+					if strings.HasSuffix(arg.Type, "List") {
+						requiredCheck = fmt.Sprintf("\nif len(c.%s) <= 0 {", arg.Name)
+						optionalCheck = fmt.Sprintf("\nif len(c.%s) > 0 {", arg.Name)
+					} else {
+						requiredCheck = fmt.Sprintf("\nif c.%s == nil {", arg.Name)
+						optionalCheck = fmt.Sprintf("\nif c.%s != nil {", arg.Name)
+					}
 				}
-			}
 
-			buf.WriteString("\n")
-			if arg.Required {
-				buf.WriteString(requiredCheck)
-				fmt.Fprintf(&buf, "\nreturn nil, errors.New(`missing required parameter %s`)", arg.Name)
-				buf.WriteString("\n}")
-				if len(prelude) > 0 {
-					buf.WriteString(prelude)
-				}
-			} else {
-				buf.WriteString(optionalCheck)
-				if len(prelude) > 0 {
-					buf.WriteString(prelude)
+				buf.WriteString("\n")
+				if arg.Required {
+					buf.WriteString(requiredCheck)
+					fmt.Fprintf(&buf, "\nreturn nil, errors.New(`missing required parameter %s`)", arg.Name)
+					buf.WriteString("\n}")
+					if len(prelude) > 0 {
+						buf.WriteString(prelude)
+					}
+				} else {
+					buf.WriteString(optionalCheck)
+					if len(prelude) > 0 {
+						buf.WriteString(prelude)
+					}
 				}
-			}
 
-			var qn = arg.Name
-			if len(arg.QueryName) > 0 {
-				qn = arg.QueryName
-			}
-			fmt.Fprintf(&buf, "\nv.Set(%s,%s)", strconv.Quote(qn), assignValue)
+				var qn = arg.Name
+				if len(arg.QueryName) > 0 {
+					qn = arg.QueryName
+				}
+				fmt.Fprintf(&buf, "\nv.Set(%s,%s)", strconv.Quote(qn), assignValue)
 
-			if !arg.Required {
-				buf.WriteString("\n}")
+				if !arg.Required {
+					buf.WriteString("\n}")
+				}
 			}
+			buf.WriteString("\nreturn v, nil")
+			buf.WriteString("\n}")
+		} else {
+			generatePayloadFunc(&buf, endpoint, transport)
 		}
-		buf.WriteString("\nreturn v, nil")
-		buf.WriteString("\n}")
 
 		hasReturn := len(endpoint.ReturnType) > 0
 		var returnType string
@@ -314,7 +594,7 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 		}
 
 		fmt.Fprintf(&buf, "\n// Do executes the call to access %s endpoint", endpoint.Name)
-		fmt.Fprintf(&buf, "\nfunc (c *%s%sCall) Do(ctx context.Context) ", endpoint.Group, endpoint.methodName)
+		fmt.Fprintf(&buf, "\nfunc (c *%s%sCall) Do(ctx context.Context, opts ...CallOption) ", endpoint.Group, endpoint.methodName)
 		if hasReturn {
 			fmt.Fprintf(&buf, "(%s, error)", returnType)
 		} else {
@@ -322,7 +602,28 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 		}
 		buf.WriteString("{")
 		fmt.Fprintf(&buf, "\nconst endpoint = %s", strconv.Quote(endpoint.Name))
-		buf.WriteString("\nv, err := c.Values()")
+		tier := endpoint.Tier
+		if len(tier) == 0 {
+			tier = defaultTier
+		}
+		fmt.Fprintf(&buf, "\nconst tier = Tier(%s)", strconv.Quote(tier))
+		if endpoint.SkipToken {
+			buf.WriteString("\nconst token = \"\"")
+		} else {
+			buf.WriteString("\ntoken, err := c.service.client.resolveToken(ctx, c.service.token, c.token, c.workspaceID)")
+			buf.WriteString("\nif err != nil {")
+			buf.WriteString("\nreturn ")
+			if hasReturn {
+				buf.WriteString("nil, ")
+			}
+			buf.WriteString("errors.Wrap(err, `failed to resolve token`)")
+			buf.WriteString("\n}")
+		}
+		if transport == "form" {
+			buf.WriteString("\nv, err := c.Values(token)")
+		} else {
+			buf.WriteString("\np, err := c.Payload(token)")
+		}
 		buf.WriteString("\nif err != nil {")
 		buf.WriteString("\nreturn ")
 		if hasReturn {
@@ -340,7 +641,28 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 			}
 		}
 		buf.WriteString("\n}")
-		buf.WriteString("\nif err := c.service.client.postForm(ctx, endpoint, v, &res); err != nil {")
+		authHeader := "false"
+		if endpoint.AuthHeader {
+			authHeader = "true"
+		}
+		switch transport {
+		case "form":
+			fmt.Fprintf(&buf, "\nstatus, err := c.service.client.postForm(ctx, endpoint, tier, v, &res, token, %s, opts...)", authHeader)
+		case "json":
+			fmt.Fprintf(&buf, "\nstatus, err := c.service.client.postJSON(ctx, endpoint, tier, p, &res, token, %s, opts...)", authHeader)
+		case "multipart":
+			fileArg := ""
+			for _, arg := range endpoint.Args {
+				if arg.Type == "io.Reader" {
+					fileArg = arg.Name
+				}
+			}
+			if len(fileArg) == 0 {
+				return errors.Errorf(`endpoint %s uses multipart transport but declares no io.Reader arg to upload`, endpoint.Name)
+			}
+			fmt.Fprintf(&buf, "\nstatus, err := c.service.client.postMultipart(ctx, endpoint, tier, p, %s, c.%s, &res, token, %s, opts...)", strconv.Quote(fileArg), fileArg, authHeader)
+		}
+		buf.WriteString("\nif err != nil {")
 		buf.WriteString("\nreturn ")
 		if hasReturn {
 			buf.WriteString("nil, ")
@@ -353,9 +675,20 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 		if hasReturn {
 			buf.WriteString("nil, ")
 		}
-		buf.WriteString("errors.New(res.Error.String())")
+		buf.WriteString("&SlackAPIError{")
+		buf.WriteString("\nCode: res.Error,")
+		fmt.Fprintf(&buf, "\nEndpoint: %s,", strconv.Quote(endpoint.Name))
+		buf.WriteString("\nHTTPStatus: status,")
+		buf.WriteString("\nWarnings: res.ResponseMetadata.Warnings,")
+		buf.WriteString("\nMessages: res.ResponseMetadata.Messages,")
+		buf.WriteString("\n}")
 		buf.WriteString("\n}")
 
+		isCursorIterator := hasReturn && endpoint.Iterator == "cursor" && strings.HasSuffix(endpoint.ReturnType, "List")
+		if isCursorIterator {
+			buf.WriteString("\nc.nextCursor = res.ResponseMetadata.NextCursor")
+		}
+
 		buf.WriteString("\n\nreturn ")
 		if hasReturn {
 			buf.WriteString("res.")
@@ -368,6 +701,10 @@ func generateServiceDetailsFile(file string, endpoints []Endpoint) error {
 		}
 		buf.WriteString("nil")
 		buf.WriteString("\n}")
+
+		if isCursorIterator {
+			generateIteratorCode(&buf, endpoint, returnType)
+		}
 	}
 	formatted, err := format.Source(buf.Bytes())
 	if err != nil {
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestGeneratePayloadFuncRequiredFileArg guards against Payload() silently
+// posting with no file part when a required io.Reader arg is left nil.
+func TestGeneratePayloadFuncRequiredFileArg(t *testing.T) {
+	endpoint := Endpoint{
+		Group:      "Files",
+		methodName: "Upload",
+		Name:       "files.upload",
+		Args: []Argument{
+			{Name: "file", Type: "io.Reader", Required: true},
+			{Name: "channels", Type: "string", Required: false},
+		},
+	}
+
+	var buf bytes.Buffer
+	generatePayloadFunc(&buf, endpoint, "multipart")
+	out := buf.String()
+
+	if !strings.Contains(out, "if c.file == nil {") {
+		t.Fatalf("expected a nil check for the required io.Reader arg, got:\n%s", out)
+	}
+	if !strings.Contains(out, "missing required parameter file") {
+		t.Fatalf("expected the missing-required-parameter error for file, got:\n%s", out)
+	}
+	if strings.Contains(out, `p["file"]`) {
+		t.Fatalf("io.Reader args must not be added to the payload map, got:\n%s", out)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestGenerateIteratorCode pins down the control flow of the emitted cursor
+// iterator: Next must fetch another page via Do/Cursor whenever the current
+// page is exhausted, and stop once the endpoint reports no next_cursor.
+func TestGenerateIteratorCode(t *testing.T) {
+	endpoint := Endpoint{
+		Group:      "Channels",
+		methodName: "List",
+		Name:       "channels.list",
+	}
+
+	var buf bytes.Buffer
+	generateIteratorCode(&buf, endpoint, "objects.ChannelList")
+	out := buf.String()
+
+	for _, want := range []string{
+		"type ChannelsListIterator struct",
+		"func (c *ChannelsListCall) Iter(ctx context.Context) *ChannelsListIterator {",
+		"func (it *ChannelsListIterator) Next() bool {",
+		"page, err := it.call.Do(it.ctx)",
+		"if len(it.call.nextCursor) == 0 {",
+		"it.call.Cursor(it.call.nextCursor)",
+		"func (it *ChannelsListIterator) Item() *objects.Channel {",
+		"func (it *ChannelsListIterator) Err() error {",
+		"func (c *ChannelsListCall) Pages(ctx context.Context, f func(objects.ChannelList) error) error {",
+		"if len(c.nextCursor) == 0 {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated iterator code missing %q\n---\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// NextFunc is the step a Middleware calls to continue the request, either
+// into the next middleware in the chain or the real HTTP round trip.
+type NextFunc func(ctx context.Context, endpoint string, v url.Values) (*http.Response, error)
+
+// Middleware wraps a single outgoing request for cross-cutting concerns
+// like logging, metrics, tracing, or request-ID propagation. It must call
+// next to continue the call; returning without calling it short-circuits
+// the request.
+type Middleware func(ctx context.Context, endpoint string, v url.Values, next NextFunc) (*http.Response, error)
+
+// CallOption configures a single Do call, layered on top of whatever
+// middlewares the client itself was built with via WithMiddleware.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	middlewares []Middleware
+}
+
+// WithCallMiddleware adds middleware that wraps only this one Do call,
+// outermost to innermost in the order the client's own middlewares run,
+// then these.
+func WithCallMiddleware(m ...Middleware) CallOption {
+	return func(cfg *callConfig) { cfg.middlewares = append(cfg.middlewares, m...) }
+}
+
+func newCallConfig(opts ...CallOption) *callConfig {
+	cfg := &callConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// chainMiddleware composes middlewares around terminal into a single
+// NextFunc, the first middleware in the slice being outermost.
+func chainMiddleware(middlewares []Middleware, terminal NextFunc) NextFunc {
+	next := terminal
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		mw, inner := middlewares[i], next
+		next = func(ctx context.Context, endpoint string, v url.Values) (*http.Response, error) {
+			return mw(ctx, endpoint, v, inner)
+		}
+	}
+	return next
+}
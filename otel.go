@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewOTelMiddleware returns a Middleware that wraps every call in a span
+// named "slack.<endpoint>", tagged with the team the request was sent to
+// (when a team_id parameter is present) and, once the response comes back,
+// any warning or error code Slack reported. Pass nil to use the global
+// tracer provider.
+func NewOTelMiddleware(tracer trace.Tracer) Middleware {
+	if tracer == nil {
+		tracer = otel.Tracer("github.com/lestrrat/go-slack")
+	}
+
+	return func(ctx context.Context, endpoint string, v url.Values, next NextFunc) (*http.Response, error) {
+		attrs := []attribute.KeyValue{attribute.String("slack.method", endpoint)}
+		if team := v.Get("team_id"); len(team) > 0 {
+			attrs = append(attrs, attribute.String("slack.team", team))
+		}
+		ctx, span := tracer.Start(ctx, "slack."+endpoint, trace.WithAttributes(attrs...))
+		defer span.End()
+
+		res, err := next(ctx, endpoint, v)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return res, err
+		}
+
+		if body, ok := peekBody(res); ok {
+			var probe struct {
+				Error   string `json:"error"`
+				Warning string `json:"warning"`
+			}
+			if json.Unmarshal(body, &probe) == nil {
+				if len(probe.Error) > 0 {
+					span.SetAttributes(attribute.String("slack.error", probe.Error))
+					span.SetStatus(codes.Error, probe.Error)
+				}
+				if len(probe.Warning) > 0 {
+					span.SetAttributes(attribute.String("slack.warning", probe.Warning))
+				}
+			}
+		}
+		return res, nil
+	}
+}
+
+// peekBody reads res.Body and restores it so later middleware or the final
+// decode step still see the full response.
+func peekBody(res *http.Response) ([]byte, bool) {
+	if res == nil || res.Body == nil {
+		return nil, false
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false
+	}
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return body, true
+}
@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewPrometheusMiddleware returns a Middleware that exports, per endpoint,
+// a request latency histogram ("slack_request_duration_seconds") and an
+// error counter ("slack_request_errors_total") labeled by the Slack error
+// code returned. It registers both collectors against reg (pass nil to
+// use prometheus.DefaultRegisterer). Calling this more than once against
+// the same reg (e.g. one Service per workspace) reuses the collectors
+// already registered there instead of panicking.
+func NewPrometheusMiddleware(reg prometheus.Registerer) Middleware {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	latency := registerOrReuse(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "slack_request_duration_seconds",
+		Help:    "Latency of Slack Web API calls by endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})).(*prometheus.HistogramVec)
+	errorCount := registerOrReuse(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_request_errors_total",
+		Help: "Count of Slack Web API errors by endpoint and error code.",
+	}, []string{"endpoint", "error"})).(*prometheus.CounterVec)
+
+	return func(ctx context.Context, endpoint string, v url.Values, next NextFunc) (*http.Response, error) {
+		start := time.Now()
+		res, err := next(ctx, endpoint, v)
+		latency.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+		if err != nil {
+			errorCount.WithLabelValues(endpoint, "transport").Inc()
+			return res, err
+		}
+
+		if body, ok := peekBody(res); ok {
+			var probe struct {
+				Error string `json:"error"`
+			}
+			if json.Unmarshal(body, &probe) == nil && len(probe.Error) > 0 {
+				errorCount.WithLabelValues(endpoint, probe.Error).Inc()
+			}
+		}
+		return res, nil
+	}
+}
+
+// registerOrReuse registers collector against reg, returning it unchanged.
+// If an equivalent collector (same name and labels) is already registered,
+// it returns that existing collector instead of panicking, so building a
+// second Service against a shared Registerer (e.g. one per workspace) is
+// safe.
+func registerOrReuse(reg prometheus.Registerer, collector prometheus.Collector) prometheus.Collector {
+	if err := reg.Register(collector); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			return already.ExistingCollector
+		}
+		panic(err)
+	}
+	return collector
+}
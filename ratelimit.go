@@ -0,0 +1,122 @@
+package slack
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Tier identifies one of Slack's Web API rate limit tiers. The generator
+// assigns each generated Call the tier declared for its endpoint in
+// endpoints.json (or "tier3" if unset) and passes it to httpClient.postForm
+// so the RateLimiter can apply the right budget per method.
+type Tier string
+
+// Slack's documented per-workspace rate limit tiers. These are used as the
+// default bucket sizes for newDefaultRateLimiter; callers that need the
+// exact limits Slack grants their app can supply their own RateLimiter via
+// WithRateLimiter instead.
+const (
+	TierNone Tier = ""
+	Tier1    Tier = "tier1"
+	Tier2    Tier = "tier2"
+	Tier3    Tier = "tier3"
+	Tier4    Tier = "tier4"
+)
+
+// tierRatePerMinute are Slack's published requests-per-minute budgets per
+// tier. TierNone is treated as unlimited since it covers endpoints Slack
+// doesn't rate limit at all (e.g. api.test).
+var tierRatePerMinute = map[Tier]float64{
+	Tier1: 1,
+	Tier2: 20,
+	Tier3: 50,
+	Tier4: 100,
+}
+
+// RateLimiter controls how quickly httpClient is allowed to issue requests
+// for a given Tier. key scopes the budget below the tier: httpClient passes
+// the resolved token, since Slack grants each tier's budget per workspace,
+// not per process. Wait blocks until a request may proceed, or returns an
+// error if ctx is done first.
+type RateLimiter interface {
+	Wait(ctx context.Context, tier Tier, key string) error
+}
+
+// tokenBucket is a simple, dependency-free token bucket: it refills
+// continuously at refillPerSec and allows a burst up to max tokens.
+type tokenBucket struct {
+	mu           sync.Mutex
+	tokens       float64
+	max          float64
+	refillPerSec float64
+	last         time.Time
+}
+
+func newTokenBucket(ratePerMinute float64) *tokenBucket {
+	return &tokenBucket{
+		tokens:       ratePerMinute,
+		max:          ratePerMinute,
+		refillPerSec: ratePerMinute / 60,
+		last:         time.Now(),
+	}
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = math.Min(b.max, b.tokens+now.Sub(b.last).Seconds()*b.refillPerSec)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.refillPerSec * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// tierRateLimiter is the default RateLimiter: one token bucket per
+// (tier, key) pair, sized after Slack's documented per-minute budgets and
+// created lazily on first use, so each workspace/token sharing an
+// httpClient gets its own independent budget instead of throttling every
+// other workspace on the same client. TierNone never waits.
+type tierRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[Tier]map[string]*tokenBucket
+}
+
+func newDefaultRateLimiter() *tierRateLimiter {
+	return &tierRateLimiter{buckets: make(map[Tier]map[string]*tokenBucket)}
+}
+
+func (rl *tierRateLimiter) Wait(ctx context.Context, tier Tier, key string) error {
+	rate, ok := tierRatePerMinute[tier]
+	if !ok {
+		return nil
+	}
+
+	rl.mu.Lock()
+	perKey, ok := rl.buckets[tier]
+	if !ok {
+		perKey = make(map[string]*tokenBucket)
+		rl.buckets[tier] = perKey
+	}
+	b, ok := perKey[key]
+	if !ok {
+		b = newTokenBucket(rate)
+		perKey[key] = b
+	}
+	rl.mu.Unlock()
+
+	return b.wait(ctx)
+}
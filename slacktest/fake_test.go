@@ -0,0 +1,114 @@
+package slacktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// withRequiredParams registers required, restoring whatever was there
+// before once the test finishes, since requiredParams is the
+// package-level map requirements_generated.go populates for real
+// endpoints.
+func withRequiredParams(t *testing.T, endpoint string, required []string) {
+	t.Helper()
+	prev, had := requiredParams[endpoint]
+	requiredParams[endpoint] = required
+	t.Cleanup(func() {
+		if had {
+			requiredParams[endpoint] = prev
+		} else {
+			delete(requiredParams, endpoint)
+		}
+	})
+}
+
+func TestFirstMissingParamJSONNonStringTypes(t *testing.T) {
+	withRequiredParams(t, "chat.postMessage", []string{"as_user", "blocks"})
+
+	body, err := json.Marshal(map[string]interface{}{
+		"as_user": true,
+		"blocks":  []interface{}{map[string]interface{}{"type": "section"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/chat.postMessage", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	if got := firstMissingParam("chat.postMessage", r); got != "" {
+		t.Fatalf("got missing param %q, want none: bool/list fields were correctly supplied", got)
+	}
+}
+
+func TestFirstMissingParamJSONMissingField(t *testing.T) {
+	withRequiredParams(t, "chat.postMessage", []string{"as_user"})
+
+	body, err := json.Marshal(map[string]interface{}{"channel": "C123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/chat.postMessage", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	if got := firstMissingParam("chat.postMessage", r); got != "as_user" {
+		t.Fatalf("got %q, want %q", got, "as_user")
+	}
+}
+
+func TestFirstMissingParamMultipartFile(t *testing.T) {
+	withRequiredParams(t, "files.upload", []string{"file"})
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write([]byte("contents")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/files.upload", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	if got := firstMissingParam("files.upload", r); got != "" {
+		t.Fatalf("got missing param %q, want none: a real file part was sent", got)
+	}
+}
+
+func TestFirstMissingParamMultipartFileMissing(t *testing.T) {
+	withRequiredParams(t, "files.upload", []string{"file"})
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("channels", "C123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/files.upload", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	if got := firstMissingParam("files.upload", r); got != "file" {
+		t.Fatalf("got %q, want %q", got, "file")
+	}
+}
+
+func TestFirstMissingParamNoRequirements(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api.test", strings.NewReader(""))
+	if got := firstMissingParam("api.test", r); got != "" {
+		t.Fatalf("got %q, want none for an endpoint with no required params", got)
+	}
+}
@@ -0,0 +1,170 @@
+package slacktest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Recorder is an http.RoundTripper that forwards requests to Upstream and
+// writes the response body to a fixture file under Dir, keyed by endpoint
+// and request body, so a later test run can replay it with Replayer
+// instead of hitting Slack again.
+type Recorder struct {
+	Dir      string
+	Upstream http.RoundTripper
+}
+
+// NewRecorder returns a Recorder that writes fixtures under dir, forwarding
+// requests through upstream (http.DefaultTransport if nil).
+func NewRecorder(dir string, upstream http.RoundTripper) *Recorder {
+	if upstream == nil {
+		upstream = http.DefaultTransport
+	}
+	return &Recorder{Dir: dir, Upstream: upstream}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rec *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	res, err := rec.Upstream.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	if err := os.MkdirAll(rec.Dir, 0755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(rec.Dir, fixtureKey(req, reqBody)+".json")
+	if err := os.WriteFile(path, resBody, 0644); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Replayer is an http.RoundTripper that serves fixtures previously captured
+// by Recorder instead of making any network call.
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer returns a Replayer that serves fixtures from dir.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rep *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(rep.Dir, fixtureKey(req, reqBody)+".json")
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("slacktest: no fixture for %s %s: %w", req.Method, req.URL.Path, err)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// fixtureKey derives a stable filename from the endpoint path and request
+// body so the same call with the same arguments always maps to the same
+// fixture, independent of map/query key ordering.
+func fixtureKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, req.Method)
+	io.WriteString(h, " ")
+	io.WriteString(h, req.URL.Path)
+	io.WriteString(h, " ")
+	h.Write(normalizeBody(req, body))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// normalizeBody re-sorts JSON object bodies, and the fields of multipart
+// bodies, by key so the hash depends neither on map iteration order nor on
+// the random multipart boundary. url.Values.Encode() already sorts its
+// keys, so form-encoded bodies hash consistently as-is and are returned
+// unchanged.
+func normalizeBody(req *http.Request, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var values map[string]string
+	var err error
+	if mediaType, params, perr := mime.ParseMediaType(req.Header.Get("Content-Type")); perr == nil && strings.HasPrefix(mediaType, "multipart/") {
+		values = map[string]string{}
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for part, perr := mr.NextPart(); perr == nil; part, perr = mr.NextPart() {
+			b, _ := io.ReadAll(part)
+			values[part.FormName()] = string(b)
+		}
+	} else {
+		values, err = parseFormBody(body)
+	}
+	if err != nil {
+		return body
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s=%s&", k, values[k])
+	}
+	return buf.Bytes()
+}
+
+func parseFormBody(body []byte) (map[string]string, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(payload))
+	for k, v := range payload {
+		out[k] = fmt.Sprintf("%v", v)
+	}
+	return out, nil
+}
@@ -0,0 +1,10 @@
+package slacktest
+
+// requiredParams maps a Slack endpoint name to the form/JSON field names
+// its generated Call treats as required. This file is normally produced by
+// internal/cmd/genmethods/genmethods.go's generateSlacktestRequirementsFile,
+// which overwrites it with one entry per endpoint in endpoints.json. This
+// hand-written fallback ships an empty map so slacktest compiles standalone
+// before generation has ever run; go generate replaces it with the real
+// data.
+var requiredParams = map[string][]string{}
@@ -0,0 +1,66 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetrySucceedsAfterTransientRateLimit(t *testing.T) {
+	c := &httpClient{maxRetries: 3, backoff: func(int) time.Duration { return 0 }}
+
+	attempts := 0
+	status, err := c.withRetry(context.Background(), "chat.postMessage", Tier3, "tok", func() (int, string, bool, error) {
+		attempts++
+		if attempts == 1 {
+			return 429, "0", true, errors.New("rate limited by slack")
+		}
+		return 200, "", false, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != 200 {
+		t.Fatalf("got status %d, want 200", status)
+	}
+	if attempts != 2 {
+		t.Fatalf("got %d attempts, want 2", attempts)
+	}
+}
+
+func TestWithRetryReturnsSlackAPIErrorOnceExhausted(t *testing.T) {
+	c := &httpClient{maxRetries: 2, backoff: func(int) time.Duration { return 0 }}
+
+	attempts := 0
+	_, err := c.withRetry(context.Background(), "chat.postMessage", Tier3, "tok", func() (int, string, bool, error) {
+		attempts++
+		return 429, "0", true, errors.New("rate limited by slack")
+	})
+
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3 (1 + maxRetries)", attempts)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected errors.Is(err, ErrRateLimited) to hold, got %v", err)
+	}
+}
+
+func TestWithRetryReturnsImmediatelyOnNonRateLimitError(t *testing.T) {
+	c := &httpClient{maxRetries: 3, backoff: func(int) time.Duration { return 0 }}
+
+	attempts := 0
+	wantErr := errors.New("channel not found")
+	_, err := c.withRetry(context.Background(), "chat.postMessage", Tier3, "tok", func() (int, string, bool, error) {
+		attempts++
+		return 404, "", false, wantErr
+	})
+
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want 1 (no retry on non-rate-limit error)", attempts)
+	}
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got %v, want %v", err, wantErr)
+	}
+}